@@ -16,15 +16,83 @@
 package collector
 
 import (
+	"flag"
 	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/coreos/go-systemd/dbus"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+// Minimum systemd version required for the SystemState manager property this
+// collector relies on, which isn't present on every systemd this exporter
+// supports.
+const systemStateMinVersion = 212
+
+// Backoff bounds applied between dbus reconnect attempts after a transport
+// error, so a flapping daemon doesn't get hammered with connection attempts.
+const (
+	minDbusReconnectBackoff = 100 * time.Millisecond
+	maxDbusReconnectBackoff = 30 * time.Second
+)
+
+var systemdVersionRE = regexp.MustCompile(`^"?v?(\d+)`)
+
+// parseSystemdVersion extracts the leading major version number out of a
+// systemd manager Version property value, e.g. `"239.3-1"` -> 239. The
+// property is rendered as a quoted dbus string, and distributions commonly
+// append a suffix (`-1ubuntu1`, `~rc1`), so only the leading integer is used.
+func parseSystemdVersion(v string) (int, error) {
+	matches := systemdVersionRE.FindStringSubmatch(v)
+	if len(matches) != 2 {
+		return 0, fmt.Errorf("couldn't find a version number in %q", v)
+	}
+	return strconv.Atoi(matches[1])
+}
+
+var (
+	unitIncludeSet = flag.String("collector.systemd.unit-include", ".+", "Regexp of systemd units to include. Units must both match include and not match exclude to be included.")
+	unitExcludeSet = flag.String("collector.systemd.unit-exclude", "", "Regexp of systemd units to exclude. Units must both match include and not match exclude to be included.")
+
+	enableStartTimeMetrics = flag.Bool("collector.systemd.enable-start-time-metrics", false, "Enables unit start time metrics. This is slow on hosts with a large number of units.")
+	enableRestartsMetrics  = flag.Bool("collector.systemd.enable-restarts-metrics", false, "Enables service unit restart count metrics. This is slow on hosts with a large number of units.")
+	enableTaskMetrics      = flag.Bool("collector.systemd.enable-task-metrics", false, "Enables unit task count metrics. This is slow on hosts with a large number of units.")
 )
 
 type systemdCollector struct {
-	unitDesc          *prometheus.Desc
-	systemRunningDesc *prometheus.Desc
+	unitDesc           *prometheus.Desc
+	unitsDesc          *prometheus.Desc
+	systemRunningDesc  *prometheus.Desc
+	versionDesc        *prometheus.Desc
+	dbusReconnectsDesc *prometheus.Desc
+	scrapeDurationDesc *prometheus.Desc
+
+	unitStartTimeDesc             *prometheus.Desc
+	serviceRestartsDesc           *prometheus.Desc
+	tasksCurrentDesc              *prometheus.Desc
+	tasksMaxDesc                  *prometheus.Desc
+	socketRefusedConnectionsDesc  *prometheus.Desc
+	socketConnectionsDesc         *prometheus.Desc
+	socketAcceptedConnectionsDesc *prometheus.Desc
+	timerLastTriggerDesc          *prometheus.Desc
+
+	unitIncludePattern *regexp.Regexp
+	unitExcludePattern *regexp.Regexp
+
+	warnSystemStateOnce sync.Once
+
+	connMu             sync.Mutex
+	conn               *dbus.Conn
+	connBackoff        time.Duration
+	nextConnectAttempt time.Time
+	everConnected      bool
+	dbusReconnects     uint64
 }
 
 var unitStatesName = []string{"active", "activating", "deactivating", "inactive", "failed"}
@@ -38,38 +106,265 @@ func init() {
 func NewSystemdCollector() (Collector, error) {
 	const subsystem = "systemd"
 
+	// Tagging with "type" changes this series' identity: dashboards and
+	// recording rules keyed on the old {"name","state"} label set will see
+	// these as new series. That break is requested behavior, not an oversight.
 	unitDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(Namespace, subsystem, "unit_state"),
-		"Systemd unit", []string{"name", "state"}, nil,
+		"Systemd unit", []string{"name", "state", "type"}, nil,
+	)
+	unitsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "units"),
+		"Summary of systemd units by type and state.",
+		[]string{"state", "type"}, nil,
 	)
 	systemRunningDesc := prometheus.NewDesc(
 		prometheus.BuildFQName(Namespace, subsystem, "system_running"),
 		"Whether the system is operational (see 'systemctl is-system-running')",
 		nil, nil,
 	)
+	unitStartTimeDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "unit_start_time_seconds"),
+		"Start time of the unit since unix epoch in seconds.",
+		[]string{"name"}, nil,
+	)
+	serviceRestartsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "service_restart_total"),
+		"Service unit count of Restart triggers",
+		[]string{"name"}, nil,
+	)
+	tasksCurrentDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "unit_tasks_current"),
+		"Current number of tasks per Unit",
+		[]string{"name"}, nil,
+	)
+	tasksMaxDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "unit_tasks_max"),
+		"Maximum number of tasks per Unit",
+		[]string{"name"}, nil,
+	)
+	socketRefusedConnectionsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "socket_refused_connections_total"),
+		"Total number of refused connections for a socket.",
+		[]string{"name"}, nil,
+	)
+	socketConnectionsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "socket_current_connections"),
+		"Current number of connections for a socket.",
+		[]string{"name"}, nil,
+	)
+	socketAcceptedConnectionsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "socket_accepted_connections_total"),
+		"Total number of accepted connections for a socket.",
+		[]string{"name"}, nil,
+	)
+	timerLastTriggerDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "timer_last_trigger_seconds"),
+		"Seconds since epoch of the last trigger.",
+		[]string{"name"}, nil,
+	)
+	versionDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "version"),
+		"Detected systemd version.",
+		[]string{"version"}, nil,
+	)
+	dbusReconnectsDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "dbus_reconnects_total"),
+		"Total number of times this collector had to reconnect to dbus.",
+		nil, nil,
+	)
+	scrapeDurationDesc := prometheus.NewDesc(
+		prometheus.BuildFQName(Namespace, subsystem, "scrape_duration_seconds"),
+		"Duration of the last systemd collector scrape.",
+		nil, nil,
+	)
+
+	unitIncludePattern, err := regexp.Compile(anchorUnitPattern(*unitIncludeSet))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't compile --collector.systemd.unit-include: %s", err)
+	}
+	var unitExcludePattern *regexp.Regexp
+	if *unitExcludeSet != "" {
+		unitExcludePattern, err = regexp.Compile(anchorUnitPattern(*unitExcludeSet))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't compile --collector.systemd.unit-exclude: %s", err)
+		}
+	}
+
+	c := &systemdCollector{
+		unitDesc:                      unitDesc,
+		unitsDesc:                     unitsDesc,
+		systemRunningDesc:             systemRunningDesc,
+		versionDesc:                   versionDesc,
+		dbusReconnectsDesc:            dbusReconnectsDesc,
+		scrapeDurationDesc:            scrapeDurationDesc,
+		unitStartTimeDesc:             unitStartTimeDesc,
+		serviceRestartsDesc:           serviceRestartsDesc,
+		tasksCurrentDesc:              tasksCurrentDesc,
+		tasksMaxDesc:                  tasksMaxDesc,
+		socketRefusedConnectionsDesc:  socketRefusedConnectionsDesc,
+		socketConnectionsDesc:         socketConnectionsDesc,
+		socketAcceptedConnectionsDesc: socketAcceptedConnectionsDesc,
+		timerLastTriggerDesc:          timerLastTriggerDesc,
+		unitIncludePattern:            unitIncludePattern,
+		unitExcludePattern:            unitExcludePattern,
+	}
 
-	return &systemdCollector{
-		unitDesc:          unitDesc,
-		systemRunningDesc: systemRunningDesc,
-	}, nil
+	// The systemd version is detected lazily in Update, not here: touching dbus at
+	// construction would mean a node_exporter started before PID1/dbus is up
+	// (common at boot) permanently loses the systemd collector for the
+	// process lifetime, defeating the reconnect-with-backoff behavior that's
+	// meant to handle exactly that at scrape time.
+	return c, nil
+}
+
+// getConnLocked returns the cached dbus connection, lazily (re-)establishing
+// it with an exponential backoff if it isn't currently open. Callers must
+// hold connMu for the entire window in which the returned connection is
+// used: Prometheus does not serialize concurrent scrapes, and an Update that
+// released the lock before finishing with conn could have it closed out
+// from under it by another Update's invalidateConnLocked.
+func (c *systemdCollector) getConnLocked() (*dbus.Conn, error) {
+	if c.conn != nil {
+		return c.conn, nil
+	}
+
+	if !c.nextConnectAttempt.IsZero() && time.Now().Before(c.nextConnectAttempt) {
+		return nil, fmt.Errorf("not reconnecting to dbus until %s, after a previous connection failure", c.nextConnectAttempt)
+	}
+
+	conn, err := dbus.New()
+	if err != nil {
+		if c.connBackoff == 0 {
+			c.connBackoff = minDbusReconnectBackoff
+		} else if c.connBackoff *= 2; c.connBackoff > maxDbusReconnectBackoff {
+			c.connBackoff = maxDbusReconnectBackoff
+		}
+		c.nextConnectAttempt = time.Now().Add(c.connBackoff)
+		return nil, fmt.Errorf("couldn't get dbus connection: %s", err)
+	}
+
+	if c.everConnected {
+		c.dbusReconnects++
+	}
+	c.everConnected = true
+	c.connBackoff = 0
+	c.nextConnectAttempt = time.Time{}
+	c.conn = conn
+	return c.conn, nil
+}
+
+// invalidateConnLocked closes and drops the cached connection so the next
+// getConnLocked call re-establishes it, after a caller observes a
+// transport-level error. Caller must hold connMu.
+func (c *systemdCollector) invalidateConnLocked() {
+	if c.conn != nil {
+		c.conn.Close()
+		c.conn = nil
+	}
+}
+
+// isDbusDisconnectErr reports whether err indicates the dbus transport
+// itself has gone away, as opposed to e.g. a unit not existing.
+func isDbusDisconnectErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == io.EOF {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "use of closed network connection") ||
+		strings.Contains(msg, "connection closed by user") ||
+		strings.Contains(msg, "disconnected")
 }
 
 func (c *systemdCollector) Update(ch chan<- prometheus.Metric) (err error) {
-	units, err := c.listUnits()
+	start := time.Now()
+	defer func() {
+		ch <- prometheus.MustNewConstMetric(c.scrapeDurationDesc, prometheus.GaugeValue, time.Since(start).Seconds())
+	}()
+
+	// Prometheus does not serialize calls to Update across concurrent
+	// scrapes, but the cached dbus connection isn't safe for one scrape to
+	// invalidate while another is still using it. Hold connMu for the whole
+	// reuse-and-use window rather than just around the pointer swap.
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+
+	conn, err := c.getConnLocked()
 	if err != nil {
+		return err
+	}
+
+	versionProp, err := conn.GetManagerProperty("Version")
+	if err != nil {
+		if isDbusDisconnectErr(err) {
+			c.invalidateConnLocked()
+		}
+		return fmt.Errorf("couldn't get systemd version: %s", err)
+	}
+	c.collectVersionMetric(ch, versionProp)
+	version, err := parseSystemdVersion(versionProp)
+	if err != nil {
+		log.Debugf("couldn't parse systemd version %q, version-gated metrics stay disabled: %s", versionProp, err)
+	}
+
+	allUnits, err := conn.ListUnits()
+	if err != nil {
+		if isDbusDisconnectErr(err) {
+			c.invalidateConnLocked()
+		}
 		return fmt.Errorf("couldn't get units states: %s", err)
 	}
+	units := c.filterUnits(allUnits)
 	c.collectUnitStatusMetrics(ch, units)
+	c.collectUnitExtendedMetrics(conn, ch, units)
 
-	systemState, err := c.getSystemState()
-	if err != nil {
-		return fmt.Errorf("couldn't get system state: %s", err)
+	if version >= systemStateMinVersion {
+		systemState, err := conn.GetManagerProperty("SystemState")
+		if err != nil {
+			if isDbusDisconnectErr(err) {
+				c.invalidateConnLocked()
+			}
+			return fmt.Errorf("couldn't get system state: %s", err)
+		}
+		c.collectSystemState(ch, systemState)
+	} else {
+		c.warnSystemStateOnce.Do(func() {
+			log.Warnf("systemd version %d is older than %d, not collecting node_systemd_system_running", version, systemStateMinVersion)
+		})
 	}
-	c.collectSystemState(ch, systemState)
+
+	ch <- prometheus.MustNewConstMetric(c.dbusReconnectsDesc, prometheus.CounterValue, float64(c.dbusReconnects))
 
 	return nil
 }
 
+// anchorUnitPattern anchors a user-supplied unit-name regexp to the whole
+// name, so e.g. --collector.systemd.unit-exclude=ssh.service doesn't also
+// match openssh.service or anything else merely containing the pattern.
+func anchorUnitPattern(pattern string) string {
+	return "^(?:" + pattern + ")$"
+}
+
+// filterUnits drops units that don't match unitIncludePattern, or that match
+// unitExcludePattern, to bound the number of units the expensive per-unit
+// property lookups are run against.
+func (c *systemdCollector) filterUnits(units []dbus.UnitStatus) []dbus.UnitStatus {
+	filtered := make([]dbus.UnitStatus, 0, len(units))
+	for _, unit := range units {
+		if !c.unitIncludePattern.MatchString(unit.Name) {
+			continue
+		}
+		if c.unitExcludePattern != nil && c.unitExcludePattern.MatchString(unit.Name) {
+			continue
+		}
+		filtered = append(filtered, unit)
+	}
+	return filtered
+}
+
 func (c *systemdCollector) collectUnitStatusMetrics(ch chan<- prometheus.Metric, units []dbus.UnitStatus) {
 	for _, unit := range units {
 		for _, stateName := range unitStatesName {
@@ -79,35 +374,194 @@ func (c *systemdCollector) collectUnitStatusMetrics(ch chan<- prometheus.Metric,
 			}
 			ch <- prometheus.MustNewConstMetric(
 				c.unitDesc, prometheus.GaugeValue, isActive,
-				unit.Name, stateName)
+				unit.Name, stateName, unitSuffix(unit.Name))
 		}
 	}
+	c.collectUnitSummaryMetrics(ch, units)
 }
 
-func (c *systemdCollector) collectSystemState(ch chan<- prometheus.Metric, systemState string) {
-	isSystemRunning := 0.0
-	if systemState == `"running"` {
-		isSystemRunning = 1.0
+// collectUnitSummaryMetrics emits node_systemd_units, a count of units by
+// (type, state), so dashboards that only need aggregates can avoid the
+// cardinality of the per-unit node_systemd_unit_state series.
+func (c *systemdCollector) collectUnitSummaryMetrics(ch chan<- prometheus.Metric, units []dbus.UnitStatus) {
+	type key struct{ unitType, state string }
+	counts := make(map[key]float64)
+	for _, unit := range units {
+		counts[key{unitSuffix(unit.Name), unit.ActiveState}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(c.unitsDesc, prometheus.GaugeValue, count, k.state, k.unitType)
 	}
-	ch <- prometheus.MustNewConstMetric(c.systemRunningDesc, prometheus.GaugeValue, isSystemRunning)
 }
 
-func (c *systemdCollector) listUnits() ([]dbus.UnitStatus, error) {
-	conn, err := dbus.New()
+// collectUnitExtendedMetrics fans the slower per-unit property lookups (the
+// opt-in start time, task and restart ones, and the socket and timer ones,
+// which always run) out across goroutines sharing conn, since a host with thousands
+// of units would otherwise serialize one D-Bus round-trip per unit.
+func (c *systemdCollector) collectUnitExtendedMetrics(conn *dbus.Conn, ch chan<- prometheus.Metric, units []dbus.UnitStatus) {
+	var wg sync.WaitGroup
+	for _, unit := range units {
+		wg.Add(1)
+		go func(unit dbus.UnitStatus) {
+			defer wg.Done()
+			c.collectUnitExtendedMetric(conn, ch, unit)
+		}(unit)
+	}
+	wg.Wait()
+}
+
+func (c *systemdCollector) collectUnitExtendedMetric(conn *dbus.Conn, ch chan<- prometheus.Metric, unit dbus.UnitStatus) {
+	if *enableStartTimeMetrics {
+		c.collectUnitStartTimeMetric(conn, ch, unit)
+	}
+	if *enableTaskMetrics {
+		c.collectUnitTasksMetrics(conn, ch, unit)
+	}
+
+	switch {
+	case strings.HasSuffix(unit.Name, ".service"):
+		if *enableRestartsMetrics {
+			c.collectServiceRestartCount(conn, ch, unit)
+		}
+	case strings.HasSuffix(unit.Name, ".socket"):
+		c.collectSocketMetrics(conn, ch, unit)
+	case strings.HasSuffix(unit.Name, ".timer"):
+		c.collectTimerMetrics(conn, ch, unit)
+	}
+}
+
+func (c *systemdCollector) collectUnitStartTimeMetric(conn *dbus.Conn, ch chan<- prometheus.Metric, unit dbus.UnitStatus) {
+	prop, err := conn.GetUnitProperty(unit.Name, "ActiveEnterTimestamp")
 	if err != nil {
-		return nil, fmt.Errorf("couldn't get dbus connection: %s", err)
+		log.Debugf("couldn't get start time for unit %s: %s", unit.Name, err)
+		return
 	}
-	units, err := conn.ListUnits()
-	conn.Close()
-	return units, err
+	startTimeUsec, ok := prop.Value.Value().(uint64)
+	if !ok || startTimeUsec == 0 {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.unitStartTimeDesc, prometheus.GaugeValue, float64(startTimeUsec)/1e6, unit.Name)
 }
 
-func (c *systemdCollector) getSystemState() (state string, err error) {
-	conn, err := dbus.New()
+func (c *systemdCollector) collectUnitTasksMetrics(conn *dbus.Conn, ch chan<- prometheus.Metric, unit dbus.UnitStatus) {
+	unitType := unitDbusType(unit.Name)
+	if unitType == "" {
+		return
+	}
+	if prop, err := conn.GetUnitTypeProperty(unit.Name, unitType, "TasksCurrent"); err == nil {
+		if v, ok := prop.Value.Value().(uint64); ok && v != ^uint64(0) {
+			ch <- prometheus.MustNewConstMetric(c.tasksCurrentDesc, prometheus.GaugeValue, float64(v), unit.Name)
+		}
+	} else {
+		log.Debugf("couldn't get TasksCurrent for unit %s: %s", unit.Name, err)
+	}
+	if prop, err := conn.GetUnitTypeProperty(unit.Name, unitType, "TasksMax"); err == nil {
+		if v, ok := prop.Value.Value().(uint64); ok && v != ^uint64(0) {
+			ch <- prometheus.MustNewConstMetric(c.tasksMaxDesc, prometheus.GaugeValue, float64(v), unit.Name)
+		}
+	} else {
+		log.Debugf("couldn't get TasksMax for unit %s: %s", unit.Name, err)
+	}
+}
+
+func (c *systemdCollector) collectServiceRestartCount(conn *dbus.Conn, ch chan<- prometheus.Metric, unit dbus.UnitStatus) {
+	prop, err := conn.GetUnitTypeProperty(unit.Name, "Service", "NRestarts")
+	if err != nil {
+		log.Debugf("couldn't get restart count for service %s: %s", unit.Name, err)
+		return
+	}
+	restarts, ok := prop.Value.Value().(uint32)
+	if !ok {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.serviceRestartsDesc, prometheus.CounterValue, float64(restarts), unit.Name)
+}
+
+func (c *systemdCollector) collectSocketMetrics(conn *dbus.Conn, ch chan<- prometheus.Metric, unit dbus.UnitStatus) {
+	if prop, err := conn.GetUnitTypeProperty(unit.Name, "Socket", "NConnections"); err == nil {
+		if v, ok := prop.Value.Value().(uint32); ok {
+			ch <- prometheus.MustNewConstMetric(c.socketConnectionsDesc, prometheus.GaugeValue, float64(v), unit.Name)
+		}
+	} else {
+		log.Debugf("couldn't get NConnections for socket %s: %s", unit.Name, err)
+	}
+	if prop, err := conn.GetUnitTypeProperty(unit.Name, "Socket", "NAccepted"); err == nil {
+		if v, ok := prop.Value.Value().(uint32); ok {
+			ch <- prometheus.MustNewConstMetric(c.socketAcceptedConnectionsDesc, prometheus.CounterValue, float64(v), unit.Name)
+		}
+	} else {
+		log.Debugf("couldn't get NAccepted for socket %s: %s", unit.Name, err)
+	}
+	if prop, err := conn.GetUnitTypeProperty(unit.Name, "Socket", "NRefused"); err == nil {
+		if v, ok := prop.Value.Value().(uint32); ok {
+			ch <- prometheus.MustNewConstMetric(c.socketRefusedConnectionsDesc, prometheus.CounterValue, float64(v), unit.Name)
+		}
+	} else {
+		log.Debugf("couldn't get NRefused for socket %s: %s", unit.Name, err)
+	}
+}
+
+func (c *systemdCollector) collectTimerMetrics(conn *dbus.Conn, ch chan<- prometheus.Metric, unit dbus.UnitStatus) {
+	prop, err := conn.GetUnitTypeProperty(unit.Name, "Timer", "LastTriggerUSec")
 	if err != nil {
-		return "", fmt.Errorf("couldn't get dbus connection: %s", err)
+		log.Debugf("couldn't get last trigger time for timer %s: %s", unit.Name, err)
+		return
 	}
-	state, err = conn.GetManagerProperty("SystemState")
-	conn.Close()
-	return state, err
+	usec, ok := prop.Value.Value().(uint64)
+	if !ok || usec == 0 {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.timerLastTriggerDesc, prometheus.GaugeValue, float64(usec)/1e6, unit.Name)
+}
+
+// unitDbusTypes maps a unit name's suffix to the D-Bus interface type used to
+// query unit-type-specific properties, e.g. "foo.service" -> "Service".
+var unitDbusTypes = map[string]string{
+	"service":   "Service",
+	"socket":    "Socket",
+	"timer":     "Timer",
+	"mount":     "Mount",
+	"automount": "Automount",
+	"device":    "Device",
+	"slice":     "Slice",
+	"scope":     "Scope",
+	"path":      "Path",
+	"target":    "Target",
+	"swap":      "Swap",
+}
+
+// unitSuffix returns the unit-name suffix used as the "type" label, e.g.
+// "foo.service" -> "service". Unit names outside unitDbusTypes are labelled
+// with an empty type rather than risking unbounded label cardinality.
+func unitSuffix(unitName string) string {
+	idx := strings.LastIndex(unitName, ".")
+	if idx == -1 {
+		return ""
+	}
+	suffix := unitName[idx+1:]
+	if _, ok := unitDbusTypes[suffix]; !ok {
+		return ""
+	}
+	return suffix
+}
+
+func unitDbusType(unitName string) string {
+	return unitDbusTypes[unitSuffix(unitName)]
+}
+
+// collectVersionMetric exposes the raw Version manager property, as a label
+// on an info-style gauge, so alerting rules and dashboards can branch on
+// systemd version without shelling out to `systemctl --version`.
+func (c *systemdCollector) collectVersionMetric(ch chan<- prometheus.Metric, rawVersion string) {
+	version := strings.Trim(rawVersion, `"`)
+	ch <- prometheus.MustNewConstMetric(c.versionDesc, prometheus.GaugeValue, 1, version)
+}
+
+func (c *systemdCollector) collectSystemState(ch chan<- prometheus.Metric, systemState string) {
+	isSystemRunning := 0.0
+	if systemState == `"running"` {
+		isSystemRunning = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(c.systemRunningDesc, prometheus.GaugeValue, isSystemRunning)
 }
@@ -0,0 +1,52 @@
+// Copyright 2015 The Prometheus Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +build !nosystemd
+
+package collector
+
+import "testing"
+
+func TestParseSystemdVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: `"239"`, want: 239},
+		{in: `"239.3-1"`, want: 239},
+		{in: `"245-0ubuntu1~rc1"`, want: 245},
+		{in: `"219"`, want: 219},
+		{in: "239", want: 239},
+		{in: `""`, wantErr: true},
+		{in: "", wantErr: true},
+		{in: `"systemd"`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := parseSystemdVersion(test.in)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("parseSystemdVersion(%q) = %d, wanted error", test.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseSystemdVersion(%q) returned unexpected error: %s", test.in, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseSystemdVersion(%q) = %d, want %d", test.in, got, test.want)
+		}
+	}
+}